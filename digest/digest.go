@@ -0,0 +1,131 @@
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"net/smtp"
+	"os"
+	"sort"
+	"time"
+
+	"autojobtracker/models"
+	"autojobtracker/notion"
+)
+
+// ghostThreshold is how long a job can sit in "Applied" with no status
+// change before the digest calls it out as likely ghosted.
+const ghostThreshold = 21 * 24 * time.Hour
+
+// Config holds the SMTP and recipient settings for a digest send.
+type Config struct {
+	SMTPHost string
+	SMTPUser string
+	SMTPPass string
+	To       string
+}
+
+// ConfigFromEnv reads SMTP_HOST, SMTP_USER, SMTP_PASS and DIGEST_TO.
+func ConfigFromEnv() Config {
+	return Config{
+		SMTPHost: os.Getenv("SMTP_HOST"),
+		SMTPUser: os.Getenv("SMTP_USER"),
+		SMTPPass: os.Getenv("SMTP_PASS"),
+		To:       os.Getenv("DIGEST_TO"),
+	}
+}
+
+// Send queries Notion for jobs added or status-changed in the last `days`
+// days, plus any "Applied" jobs stale enough to count as ghosted, and
+// emails an HTML+plaintext summary to cfg.To. It's a no-op if there's
+// nothing to report.
+func Send(cfg Config, notionToken, notionDB string, days int) error {
+	since := time.Now().AddDate(0, 0, -days)
+
+	changed, err := notion.QueryChangedSince(notionToken, notionDB, since)
+	if err != nil {
+		return fmt.Errorf("digest: querying Notion: %w", err)
+	}
+
+	ghosted, err := notion.QueryGhosted(notionToken, notionDB, time.Now().Add(-ghostThreshold))
+	if err != nil {
+		return fmt.Errorf("digest: querying ghosted jobs: %w", err)
+	}
+	changed = excludeGhosted(changed, ghosted)
+
+	if len(changed) == 0 && len(ghosted) == 0 {
+		return nil
+	}
+
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Date.Before(changed[j].Date) })
+	sort.Slice(ghosted, func(i, j int) bool { return ghosted[i].Date.Before(ghosted[j].Date) })
+
+	subject := fmt.Sprintf("Job tracker digest: %d update(s) this week", len(changed))
+	plain, html := render(changed, ghosted, since)
+	return sendMail(cfg, subject, plain, html)
+}
+
+// excludeGhosted drops any job from changed that also appears in ghosted
+// (matched by Company+Position), so a job that's both stale and recently
+// touched for an unrelated reason doesn't show up twice in the digest.
+func excludeGhosted(changed, ghosted []*models.Job) []*models.Job {
+	if len(ghosted) == 0 {
+		return changed
+	}
+
+	isGhosted := make(map[[2]string]bool, len(ghosted))
+	for _, j := range ghosted {
+		isGhosted[[2]string{j.Company, j.Position}] = true
+	}
+
+	filtered := make([]*models.Job, 0, len(changed))
+	for _, j := range changed {
+		if !isGhosted[[2]string{j.Company, j.Position}] {
+			filtered = append(filtered, j)
+		}
+	}
+	return filtered
+}
+
+func render(changed, ghosted []*models.Job, since time.Time) (plain, htmlBody string) {
+	var p, h bytes.Buffer
+
+	fmt.Fprintf(&p, "Applications added or changed since %s:\n\n", since.Format("Jan 2"))
+	fmt.Fprintf(&h, "<h2>Applications added or changed since %s</h2><ul>", since.Format("Jan 2"))
+	for _, j := range changed {
+		fmt.Fprintf(&p, "- %s at %s: %s\n", j.Position, j.Company, j.Status)
+		fmt.Fprintf(&h, "<li><b>%s</b> at %s: %s</li>",
+			html.EscapeString(j.Position), html.EscapeString(j.Company), html.EscapeString(j.Status))
+	}
+	h.WriteString("</ul>")
+
+	if len(ghosted) > 0 {
+		p.WriteString("\nLikely ghosted (no response in 3+ weeks):\n\n")
+		h.WriteString("<h2>Likely ghosted (no response in 3+ weeks)</h2><ul>")
+		for _, j := range ghosted {
+			fmt.Fprintf(&p, "- %s at %s, applied %s\n", j.Position, j.Company, j.Date.Format("Jan 2"))
+			fmt.Fprintf(&h, "<li><b>%s</b> at %s, applied %s</li>",
+				html.EscapeString(j.Position), html.EscapeString(j.Company), j.Date.Format("Jan 2"))
+		}
+		h.WriteString("</ul>")
+	}
+
+	return p.String(), h.String()
+}
+
+func sendMail(cfg Config, subject, plain, html string) error {
+	const boundary = "autojobtracker-digest"
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "To: %s\r\n", cfg.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", boundary)
+	fmt.Fprintf(&msg, "--%s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n", boundary, plain)
+	fmt.Fprintf(&msg, "--%s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s\r\n", boundary, html)
+	fmt.Fprintf(&msg, "--%s--\r\n", boundary)
+
+	auth := smtp.PlainAuth("", cfg.SMTPUser, cfg.SMTPPass, cfg.SMTPHost)
+	addr := cfg.SMTPHost + ":587"
+	return smtp.SendMail(addr, auth, cfg.SMTPUser, []string{cfg.To}, msg.Bytes())
+}
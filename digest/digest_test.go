@@ -0,0 +1,53 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"autojobtracker/models"
+)
+
+func TestExcludeGhostedDropsOverlap(t *testing.T) {
+	now := time.Now()
+	changed := []*models.Job{
+		{Company: "Acme", Position: "Engineer", Status: "Applied", Date: now},
+		{Company: "Globex", Position: "Designer", Status: "Interview", Date: now},
+	}
+	ghosted := []*models.Job{
+		{Company: "Acme", Position: "Engineer", Status: "Applied", Date: now.AddDate(0, 0, -30)},
+	}
+
+	got := excludeGhosted(changed, ghosted)
+
+	if len(got) != 1 || got[0].Company != "Globex" {
+		t.Fatalf("excludeGhosted() = %+v, want only the Globex job", got)
+	}
+}
+
+func TestExcludeGhostedNoOverlapIsUnchanged(t *testing.T) {
+	now := time.Now()
+	changed := []*models.Job{
+		{Company: "Acme", Position: "Engineer", Status: "Applied", Date: now},
+	}
+	ghosted := []*models.Job{
+		{Company: "Globex", Position: "Designer", Status: "Applied", Date: now.AddDate(0, 0, -30)},
+	}
+
+	got := excludeGhosted(changed, ghosted)
+
+	if len(got) != 1 || got[0].Company != "Acme" {
+		t.Fatalf("excludeGhosted() = %+v, want the Acme job unchanged", got)
+	}
+}
+
+func TestExcludeGhostedEmptyGhostedIsUnchanged(t *testing.T) {
+	changed := []*models.Job{
+		{Company: "Acme", Position: "Engineer", Status: "Applied", Date: time.Now()},
+	}
+
+	got := excludeGhosted(changed, nil)
+
+	if len(got) != 1 {
+		t.Fatalf("excludeGhosted() = %+v, want changed returned unchanged", got)
+	}
+}
@@ -0,0 +1,30 @@
+package digest
+
+import (
+	"log"
+	"time"
+)
+
+// RunWeekly sends a digest immediately, then every 7 days, until stop is
+// closed. Intended to run as a background goroutine alongside the normal
+// fetch/parse/write pipeline.
+func RunWeekly(cfg Config, notionToken, notionDB string, days int, stop <-chan struct{}) {
+	ticker := time.NewTicker(7 * 24 * time.Hour)
+	defer ticker.Stop()
+
+	send := func() {
+		if err := Send(cfg, notionToken, notionDB, days); err != nil {
+			log.Printf("digest: send failed: %v", err)
+		}
+	}
+
+	send()
+	for {
+		select {
+		case <-ticker.C:
+			send()
+		case <-stop:
+			return
+		}
+	}
+}
@@ -1,34 +1,24 @@
 package main
 
 import (
+	"context"
+	"encoding/csv"
 	"log"
 	"os"
-	"fmt"
 	"strings"
 	"time"
-	"bytes"
-	"io"
-	"mime"
-    "regexp"
 
+	"autojobtracker/digest"
+	"autojobtracker/models"
 	"autojobtracker/parser"
-	"autojobtracker/notion"
-    "autojobtracker/models"
+	"autojobtracker/plugins"
+	"autojobtracker/sink"
+	"autojobtracker/source"
+	"autojobtracker/sync"
 
-	"github.com/emersion/go-imap"
-	"github.com/emersion/go-imap/client"
-	"github.com/emersion/go-message/mail"
 	"github.com/joho/godotenv"
 )
 
-type RawEmail struct {
-	Subject string
-	Body    string
-    Email   string
-	Date    time.Time
-}
-
-
 func main() {
 	err := godotenv.Load()
 	if err != nil {
@@ -38,78 +28,86 @@ func main() {
 	password := os.Getenv("GMAIL_APP_PASSWORD")
 	notionToken := os.Getenv("NOTION_TOKEN")
 	notionDB := os.Getenv("NOTION_DB_ID")
-    //parser.Init(openaiKey)  
-	parser.InitLLM()
-	notion.Init(notionToken, notionDB)
-	
-	var failedJobs []models.FailedJob
 
+	if len(os.Args) > 1 && os.Args[1] == "--digest" {
+		if err := digest.Send(digest.ConfigFromEnv(), notionToken, notionDB, 7); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	parser.InitLLM()
 
-	c, err := client.DialTLS("imap.gmail.com:993", nil)
+	sinkBackend, err := sink.New(os.Getenv("SINK"), sink.Config{
+		NotionToken:           notionToken,
+		NotionDB:              notionDB,
+		SQLitePath:            os.Getenv("SQLITE_PATH"),
+		AirtableKey:           os.Getenv("AIRTABLE_API_KEY"),
+		AirtableBase:          os.Getenv("AIRTABLE_BASE_ID"),
+		AirtableTable:         os.Getenv("AIRTABLE_TABLE"),
+		SheetsCredentialsFile: os.Getenv("SHEETS_CREDENTIALS_FILE"),
+		SheetsSpreadsheetID:   os.Getenv("SHEETS_SPREADSHEET_ID"),
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer c.Logout()
+	defer sinkBackend.Close()
 
-	if err := c.Login(email, password); err != nil {
-		log.Fatal(err)
+	var failedJobs []models.FailedJob
+
+	if sourceEnv := os.Getenv("SOURCE"); sourceEnv == "" || sourceEnv == "imap" {
+		if daemon := os.Getenv("DAEMON"); daemon == "1" || daemon == "true" {
+			if weekly := os.Getenv("DIGEST_WEEKLY"); weekly == "1" || weekly == "true" {
+				go digest.RunWeekly(digest.ConfigFromEnv(), notionToken, notionDB, 7, nil)
+			}
+			runDaemon(email, password, &failedJobs, sinkBackend)
+			writeFailuresToCSV(failedJobs, models.FailedJobs)
+			return
+		}
 	}
 
-	_, err = c.Select("INBOX", false)
+	if weekly := os.Getenv("DIGEST_WEEKLY"); weekly == "1" || weekly == "true" {
+		log.Println("⚠️ DIGEST_WEEKLY requires DAEMON=1 to run continuously; this process exits after one batch, so ignoring it here. Use `--digest` on your own cron schedule instead.")
+	}
+
+	src, err := source.New(os.Getenv("SOURCE"), email, password)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer src.Close()
 
-	criteria := imap.NewSearchCriteria()
-	criteria.Since = time.Now().AddDate(0, -4, 0)
-	ids, err := c.Search(criteria)
+	since := time.Now().AddDate(0, -4, 0)
+	rawChan, err := src.Fetch(since)
 	if err != nil {
 		log.Fatal(err)
 	}
-	log.Printf("Found %d messages.\n", len(ids))
-	if len(ids) == 0 {
-		return
-	}
 
-	seqset := new(imap.SeqSet)
-	seqset.AddNum(ids...)
-	section := &imap.BodySectionName{}
-	msgChan := make(chan *imap.Message)
-	rawChan := make(chan RawEmail)
 	jobChan := make(chan *models.Job)
 
-	// Fetcher goroutine
-	go func() {
-		if err := c.Fetch(seqset, []imap.FetchItem{imap.FetchEnvelope, section.FetchItem()}, msgChan); err != nil {
-			log.Fatal(err)
-		}
-	}()
-
-	// Parser goroutine
+	// Parsing worker
 	go func() {
-		for msg := range msgChan {
-			if msg.Envelope == nil {
+		for raw := range rawChan {
+			if p := plugins.Find(raw); p != nil {
+				job, err := p.Extract(raw)
+				if err != nil {
+					log.Printf("Plugin extraction failed: %v", err)
+					failedJobs = append(failedJobs, models.FailedJob{
+						Subject: raw.Subject,
+						Body:    raw.Body,
+						Email:   raw.Email,
+						Date:    raw.Date,
+						Reason:  "Plugin extraction failed",
+					})
+					continue
+				}
+				jobChan <- job
 				continue
 			}
-			subject := strings.ToLower(msg.Envelope.Subject)
-
-			if isJobEmail(subject) {
-				body := getBodyText(msg)
-                email := getSenderEmail(msg)
-				rawChan <- RawEmail{
-					Subject: subject,
-					Body:    body,
-                    Email:  email,
-					Date:    msg.Envelope.Date,
-				}
+
+			if !isJobEmail(raw.Subject) {
+				continue
 			}
-		}
-		close(rawChan)
-	}()
 
-	// Parsing worker
-	go func() {
-		for raw := range rawChan {
 			job := parser.ParseEmail(raw.Subject, raw.Body, raw.Email, raw.Date)
 			log.Printf("Parsed job: %+v\n", job)
 			if job.Company == "" && job.Position == "" {
@@ -127,114 +125,96 @@ func main() {
 		close(jobChan)
 	}()
 
-	// Notion writer (main thread can block here)
+	// Sink writer (main thread can block here)
+	ctx := context.Background()
 	for job := range jobChan {
-		notion.UpdateOrCreate(job)
-	}
-	
-	writeFailuresToCSV(failedJobs, models.FailedJobs)
-
-}
-
-func isJobEmail(subject string) bool {
-	return strings.Contains(subject, "applied") ||
-		strings.Contains(subject, "application") ||
-		strings.Contains(subject, "thanks for applying") ||
-		strings.Contains(subject, "thanks from") ||
-		strings.Contains(subject, "follow-up") ||
-		strings.Contains(subject, "update") ||
-		strings.Contains(subject, "recruiting") ||
-		strings.Contains(subject, "thank you for applying")
-}
-
-func getSenderEmail(msg *imap.Message) string {
-	if msg == nil || msg.Envelope == nil || len(msg.Envelope.From) == 0 {
-		log.Println("No sender info in email")
-		return ""
+		if err := sinkBackend.Upsert(ctx, job); err != nil {
+			log.Printf("sink: upsert failed: %v", err)
+		}
 	}
 
-	from := msg.Envelope.From[0] // typically the sender
-	email := from.MailboxName + "@" + from.HostName
-	log.Printf("Sender email: %s", email)
-	return email
+	writeFailuresToCSV(failedJobs, models.FailedJobs)
 }
 
-func getBodyText(msg *imap.Message) string {
-	if msg == nil {
-		return ""
+// runDaemon runs forever, incrementally syncing INBOX via UID state
+// persisted in SYNC_STATE_DB (default sync_state.db) and reacting to
+// IDLE push notifications instead of re-scanning the last 4 months on
+// every run.
+func runDaemon(user, password string, failedJobs *[]models.FailedJob, sinkBackend sink.Sink) {
+	imapSrc, err := source.NewIMAPSource(user, password)
+	if err != nil {
+		log.Fatal(err)
 	}
+	defer imapSrc.Close()
 
-	section := &imap.BodySectionName{}
-	r := msg.GetBody(section)
-	if r == nil {
-		log.Println("No message body found")
-		return ""
+	dbPath := os.Getenv("SYNC_STATE_DB")
+	if dbPath == "" {
+		dbPath = "sync_state.db"
 	}
-
-	mr, err := mail.CreateReader(r)
+	store, err := sync.NewBoltStore(dbPath)
 	if err != nil {
-		log.Println("CreateReader error:", err)
-		return ""
+		log.Fatal(err)
 	}
+	defer store.Close()
 
-	var htmlBody string
-
-	for {
-		p, err := mr.NextPart()
-		if err == io.EOF {
-			log.Println("Reached end of email parts without finding text/plain")
-			break
-		}
-		if err != nil {
-			log.Println("NextPart error:", err)
-			break
-		}
-
-		contentType := p.Header.Get("Content-Type")
-		if contentType == "" {
-			log.Println("Missing Content-Type header in email part")
-			continue
-		}
+	syncer := sync.NewSyncer(imapSrc, store, "INBOX", 2*time.Minute)
+	if err := syncer.Run(func(raw source.RawEmail) {
+		handleRawEmail(raw, failedJobs, sinkBackend)
+	}); err != nil {
+		log.Fatal(err)
+	}
+}
 
-		mediaType, _, err := mime.ParseMediaType(contentType)
-		if err != nil {
-			log.Println("Failed to parse media type:", err)
-			continue
-		}
+func handleRawEmail(raw source.RawEmail, failedJobs *[]models.FailedJob, sinkBackend sink.Sink) {
+	var job *models.Job
 
-		buf := new(bytes.Buffer)
-		_, err = buf.ReadFrom(p.Body)
+	if p := plugins.Find(raw); p != nil {
+		extracted, err := p.Extract(raw)
 		if err != nil {
-			log.Println("ReadFrom error:", err)
-			continue
+			log.Printf("Plugin extraction failed: %v", err)
+			*failedJobs = append(*failedJobs, models.FailedJob{
+				Subject: raw.Subject,
+				Body:    raw.Body,
+				Email:   raw.Email,
+				Date:    raw.Date,
+				Reason:  "Plugin extraction failed",
+			})
+			return
 		}
-
-		if strings.HasPrefix(mediaType, "text/plain") {
-			body := buf.String()
-			log.Printf("Extracted plain text body (length: %d)", len(body))
-			return body
+		job = extracted
+	} else {
+		if !isJobEmail(raw.Subject) {
+			return
 		}
 
-		if strings.HasPrefix(mediaType, "text/html") {
-			htmlBody = buf.String()
+		job = parser.ParseEmail(raw.Subject, raw.Body, raw.Email, raw.Date)
+		log.Printf("Parsed job: %+v\n", job)
+		if job.Company == "" && job.Position == "" {
+			*failedJobs = append(*failedJobs, models.FailedJob{
+				Subject: raw.Subject,
+				Body:    raw.Body,
+				Email:   raw.Email,
+				Date:    raw.Date,
+				Reason:  "Empty LLM output",
+			})
+			return
 		}
 	}
 
-	if htmlBody != "" {
-		log.Println("No text/plain found, using HTML fallback")
-		return stripHTMLTags(htmlBody)
+	if err := sinkBackend.Upsert(context.Background(), job); err != nil {
+		log.Printf("sink: upsert failed: %v", err)
 	}
-
-	log.Println("No text/plain or usable html body found")
-	return ""
 }
 
-func stripHTMLTags(html string) string {
-	re := regexp.MustCompile("<[^>]*>")
-	text := re.ReplaceAllString(html, "")
-	// Optionally decode &nbsp; etc.
-	text = strings.ReplaceAll(text, "&nbsp;", " ")
-	return strings.TrimSpace(text)
+func isJobEmail(subject string) bool {
+	return strings.Contains(subject, "applied") ||
+		strings.Contains(subject, "application") ||
+		strings.Contains(subject, "thanks for applying") ||
+		strings.Contains(subject, "thanks from") ||
+		strings.Contains(subject, "follow-up") ||
+		strings.Contains(subject, "update") ||
+		strings.Contains(subject, "recruiting") ||
+		strings.Contains(subject, "thank you for applying")
 }
 
 func writeFailuresToCSV(llmFailures []models.FailedJob, notionFailures []models.FailedJob) {
@@ -244,7 +224,7 @@ func writeFailuresToCSV(llmFailures []models.FailedJob, notionFailures []models.
 		log.Println("✅ All job emails parsed + written successfully.")
 		return
 	}
-	
+
 	os.MkdirAll("unparsed", 0755)
 	f, err := os.Create("unparsed/unparsed_emails.csv")
 	if err != nil {
@@ -253,23 +233,19 @@ func writeFailuresToCSV(llmFailures []models.FailedJob, notionFailures []models.
 	}
 	defer f.Close()
 
-	f.WriteString("Date,Email,Subject,Body,Reason\n")
+	w := csv.NewWriter(f)
+	defer w.Flush()
 
+	w.Write([]string{"Date", "Email", "Subject", "Body", "Reason"})
 	for _, e := range all {
-		safeBody := strings.ReplaceAll(e.Body, "\"", "'")
-		safeBody = strings.ReplaceAll(safeBody, "\n", " ")
-		safeSubject := strings.ReplaceAll(e.Subject, "\"", "'")
-
-		line := fmt.Sprintf("\"%s\",\"%s\",\"%s\",\"%s\",\"%s\"\n",
+		w.Write([]string{
 			e.Date.Format("2006-01-02 15:04"),
 			e.Email,
-			safeSubject,
-			safeBody,
+			e.Subject,
+			e.Body,
 			e.Reason,
-		)
-		f.WriteString(line)
+		})
 	}
 
 	log.Printf("📄 Wrote %d failed jobs to unparsed_emails.csv", len(all))
 }
-
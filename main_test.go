@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"testing"
+	"time"
+
+	"autojobtracker/models"
+)
+
+func TestWriteFailuresToCSVRoundTripsSpecialCharacters(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir() error = %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	date := time.Date(2025, 7, 1, 10, 30, 0, 0, time.UTC)
+	failures := []models.FailedJob{
+		{
+			Date:    date,
+			Email:   "recruiter@acme.com",
+			Subject: `subject, with a comma`,
+			Body:    "line one\nline two with a \"quote\"",
+			Reason:  "Empty LLM output",
+		},
+	}
+
+	writeFailuresToCSV(failures, nil)
+
+	f, err := os.Open("unparsed/unparsed_emails.csv")
+	if err != nil {
+		t.Fatalf("opening written CSV: %v", err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing written CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records (including header), want 2", len(records))
+	}
+
+	row := records[1]
+	want := []string{
+		date.Format("2006-01-02 15:04"),
+		"recruiter@acme.com",
+		"subject, with a comma",
+		"line one\nline two with a \"quote\"",
+		"Empty LLM output",
+	}
+	for i, w := range want {
+		if row[i] != w {
+			t.Errorf("field %d = %q, want %q", i, row[i], w)
+		}
+	}
+}
+
+func TestWriteFailuresToCSVNoopOnNoFailures(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir() error = %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	writeFailuresToCSV(nil, nil)
+
+	if _, err := os.Stat("unparsed/unparsed_emails.csv"); !os.IsNotExist(err) {
+		t.Errorf("expected no CSV to be written when there are no failures, stat err = %v", err)
+	}
+}
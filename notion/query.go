@@ -0,0 +1,136 @@
+package notion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"autojobtracker/models"
+)
+
+const notionAPIVersion = "2022-06-28"
+
+type richText struct {
+	PlainText string `json:"plain_text"`
+}
+
+type selectOption struct {
+	Name string `json:"name"`
+}
+
+type property struct {
+	Title    []richText    `json:"title"`
+	RichText []richText    `json:"rich_text"`
+	Select   *selectOption `json:"select"`
+}
+
+func (p property) text() string {
+	if len(p.Title) > 0 {
+		return p.Title[0].PlainText
+	}
+	if len(p.RichText) > 0 {
+		return p.RichText[0].PlainText
+	}
+	return ""
+}
+
+func (p property) selectName() string {
+	if p.Select == nil {
+		return ""
+	}
+	return p.Select.Name
+}
+
+// QueryChangedSince returns every job whose page in the tracker database
+// was created or last edited on or after since, with Date set to that
+// last-edit time. It's used by the weekly digest to summarize recent
+// activity without re-deriving it from email.
+func QueryChangedSince(token, databaseID string, since time.Time) ([]*models.Job, error) {
+	filter := map[string]any{
+		"timestamp": "last_edited_time",
+		"last_edited_time": map[string]string{
+			"on_or_after": since.Format(time.RFC3339),
+		},
+	}
+	return queryJobs(token, databaseID, filter, "last_edited_time")
+}
+
+// QueryGhosted returns every job still in "Applied" status whose page was
+// created before cutoff, with Date set to that creation time. Unlike
+// QueryChangedSince, this deliberately ignores last-edited time: a job
+// that's been sitting untouched in "Applied" is exactly the case the
+// weekly digest wants to flag as likely ghosted.
+func QueryGhosted(token, databaseID string, cutoff time.Time) ([]*models.Job, error) {
+	filter := map[string]any{
+		"and": []map[string]any{
+			{
+				"property": "Status",
+				"select":   map[string]string{"equals": "Applied"},
+			},
+			{
+				"timestamp": "created_time",
+				"created_time": map[string]string{
+					"before": cutoff.Format(time.RFC3339),
+				},
+			},
+		},
+	}
+	return queryJobs(token, databaseID, filter, "created_time")
+}
+
+// queryJobs runs filter against the tracker database and builds a Job per
+// result, taking Date from whichever of the response's two timestamp
+// fields dateField names.
+func queryJobs(token, databaseID string, filter map[string]any, dateField string) ([]*models.Job, error) {
+	body, err := json.Marshal(map[string]any{"filter": filter})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("https://api.notion.com/v1/databases/%s/query", databaseID)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Notion-Version", notionAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("notion: query database: unexpected status %s", resp.Status)
+	}
+
+	var parsed struct {
+		Results []struct {
+			Properties   map[string]property `json:"properties"`
+			CreatedAt    time.Time           `json:"created_time"`
+			LastEditedAt time.Time           `json:"last_edited_time"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*models.Job, 0, len(parsed.Results))
+	for _, r := range parsed.Results {
+		date := r.LastEditedAt
+		if dateField == "created_time" {
+			date = r.CreatedAt
+		}
+		jobs = append(jobs, &models.Job{
+			Company:  r.Properties["Company"].text(),
+			Position: r.Properties["Position"].text(),
+			Status:   r.Properties["Status"].selectName(),
+			Date:     date,
+		})
+	}
+	return jobs, nil
+}
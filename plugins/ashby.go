@@ -0,0 +1,38 @@
+package plugins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"autojobtracker/models"
+	"autojobtracker/source"
+)
+
+var (
+	ashbySender  = regexp.MustCompile(`@(\w+\.)?ashbyhq\.com$`)
+	ashbySubject = regexp.MustCompile(`(?i)application (?:to|for) (.+?) at (.+)`)
+)
+
+type ashbyPlugin struct{}
+
+func (ashbyPlugin) Match(raw source.RawEmail) bool {
+	return ashbySender.MatchString(raw.Email) || strings.Contains(raw.Body, "jobs.ashbyhq.com")
+}
+
+func (ashbyPlugin) Extract(raw source.RawEmail) (*models.Job, error) {
+	m := ashbySubject.FindStringSubmatch(raw.Subject)
+	if m == nil {
+		return nil, fmt.Errorf("plugins: ashby: subject %q did not match the expected pattern", raw.Subject)
+	}
+	return &models.Job{
+		Email:    raw.Email,
+		Date:     raw.Date,
+		Position: strings.TrimSpace(m[1]),
+		Company:  strings.TrimSpace(m[2]),
+	}, nil
+}
+
+func init() {
+	Register("ashby", ashbyPlugin{})
+}
@@ -0,0 +1,38 @@
+package plugins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"autojobtracker/models"
+	"autojobtracker/source"
+)
+
+var (
+	greenhouseSender  = regexp.MustCompile(`@(\w+\.)?greenhouse\.io$`)
+	greenhouseSubject = regexp.MustCompile(`(?i)application (?:to|for) (.+?) at (.+)`)
+)
+
+type greenhousePlugin struct{}
+
+func (greenhousePlugin) Match(raw source.RawEmail) bool {
+	return greenhouseSender.MatchString(raw.Email) || strings.Contains(raw.Body, "greenhouse.io")
+}
+
+func (greenhousePlugin) Extract(raw source.RawEmail) (*models.Job, error) {
+	m := greenhouseSubject.FindStringSubmatch(raw.Subject)
+	if m == nil {
+		return nil, fmt.Errorf("plugins: greenhouse: subject %q did not match the expected pattern", raw.Subject)
+	}
+	return &models.Job{
+		Email:    raw.Email,
+		Date:     raw.Date,
+		Position: strings.TrimSpace(m[1]),
+		Company:  strings.TrimSpace(m[2]),
+	}, nil
+}
+
+func init() {
+	Register("greenhouse", greenhousePlugin{})
+}
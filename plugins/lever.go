@@ -0,0 +1,38 @@
+package plugins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"autojobtracker/models"
+	"autojobtracker/source"
+)
+
+var (
+	leverSender  = regexp.MustCompile(`@(\w+\.)?lever\.co$`)
+	leverSubject = regexp.MustCompile(`(?i)your application to (.+?) at (.+)`)
+)
+
+type leverPlugin struct{}
+
+func (leverPlugin) Match(raw source.RawEmail) bool {
+	return leverSender.MatchString(raw.Email) || strings.Contains(raw.Body, "jobs.lever.co")
+}
+
+func (leverPlugin) Extract(raw source.RawEmail) (*models.Job, error) {
+	m := leverSubject.FindStringSubmatch(raw.Subject)
+	if m == nil {
+		return nil, fmt.Errorf("plugins: lever: subject %q did not match the expected pattern", raw.Subject)
+	}
+	return &models.Job{
+		Email:    raw.Email,
+		Date:     raw.Date,
+		Position: strings.TrimSpace(m[1]),
+		Company:  strings.TrimSpace(m[2]),
+	}, nil
+}
+
+func init() {
+	Register("lever", leverPlugin{})
+}
@@ -0,0 +1,37 @@
+package plugins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"autojobtracker/models"
+	"autojobtracker/source"
+)
+
+var (
+	linkedinSender  = regexp.MustCompile(`@(\w+\.)?linkedin\.com$`)
+	linkedinSubject = regexp.MustCompile(`(?i)your application was sent to (.+)`)
+)
+
+type linkedinPlugin struct{}
+
+func (linkedinPlugin) Match(raw source.RawEmail) bool {
+	return linkedinSender.MatchString(raw.Email)
+}
+
+func (linkedinPlugin) Extract(raw source.RawEmail) (*models.Job, error) {
+	m := linkedinSubject.FindStringSubmatch(raw.Subject)
+	if m == nil {
+		return nil, fmt.Errorf("plugins: linkedin: subject %q did not match the expected pattern", raw.Subject)
+	}
+	return &models.Job{
+		Email:   raw.Email,
+		Date:    raw.Date,
+		Company: strings.TrimSpace(m[1]),
+	}, nil
+}
+
+func init() {
+	Register("linkedin", linkedinPlugin{})
+}
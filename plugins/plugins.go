@@ -0,0 +1,48 @@
+package plugins
+
+import (
+	"autojobtracker/models"
+	"autojobtracker/source"
+)
+
+// Plugin recognizes and extracts job-application emails from one specific
+// ATS (Greenhouse, Lever, Workday, ...). Plugins are cheap, deterministic
+// alternatives to the LLM parser and are always tried first.
+type Plugin interface {
+	// Match reports whether this plugin knows how to parse raw.
+	Match(raw source.RawEmail) bool
+	// Extract pulls the job fields out of an email Match has already
+	// accepted.
+	Extract(raw source.RawEmail) (*models.Job, error)
+}
+
+var (
+	registry = map[string]Plugin{}
+	order    []Plugin
+)
+
+// Register adds a plugin under name. Plugins call this from their own
+// init(), so importing the plugins package is all that's needed to wire
+// a new ATS in.
+func Register(name string, p Plugin) {
+	if _, exists := registry[name]; exists {
+		panic("plugins: duplicate registration for " + name)
+	}
+	registry[name] = p
+	order = append(order, p)
+}
+
+// Find returns the first registered plugin (in registration order) that
+// matches raw, or nil if none do and the caller should fall back to the
+// LLM parser. Registration order is deterministic across runs, unlike
+// ranging over registry directly would be, which matters because more
+// than one plugin's permissive body-contains fallback can match the same
+// email.
+func Find(raw source.RawEmail) Plugin {
+	for _, p := range order {
+		if p.Match(raw) {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package plugins
+
+import (
+	"testing"
+	"time"
+
+	"autojobtracker/models"
+	"autojobtracker/source"
+)
+
+func TestExtractParsesMatchingSubject(t *testing.T) {
+	tests := []struct {
+		name         string
+		plugin       Plugin
+		subject      string
+		wantPosition string
+		wantCompany  string
+	}{
+		{"ashby", ashbyPlugin{}, "application to Staff Engineer at Acme", "Staff Engineer", "Acme"},
+		{"greenhouse", greenhousePlugin{}, "application for Staff Engineer at Acme", "Staff Engineer", "Acme"},
+		{"lever", leverPlugin{}, "your application to Staff Engineer at Acme", "Staff Engineer", "Acme"},
+		{"wellfound", wellfoundPlugin{}, "you applied to Staff Engineer at Acme", "Staff Engineer", "Acme"},
+		{"workday", workdayPlugin{}, "thank you for applying to the staff engineer position at acme", "staff engineer", "acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := source.RawEmail{Subject: tt.subject, Date: time.Now()}
+			job, err := tt.plugin.Extract(raw)
+			if err != nil {
+				t.Fatalf("Extract() returned error: %v", err)
+			}
+			if job.Position != tt.wantPosition || job.Company != tt.wantCompany {
+				t.Errorf("Extract() = {Position: %q, Company: %q}, want {Position: %q, Company: %q}",
+					job.Position, job.Company, tt.wantPosition, tt.wantCompany)
+			}
+		})
+	}
+}
+
+func TestExtractErrorsOnNonMatchingSubject(t *testing.T) {
+	plugins := map[string]Plugin{
+		"ashby":      ashbyPlugin{},
+		"greenhouse": greenhousePlugin{},
+		"lever":      leverPlugin{},
+		"wellfound":  wellfoundPlugin{},
+		"workday":    workdayPlugin{},
+		"linkedin":   linkedinPlugin{},
+	}
+
+	// A subject that only mentions the ATS domain in the body (the loose
+	// Match() fallback) but carries none of the expected subject phrasing.
+	raw := source.RawEmail{Subject: "your weekly newsletter", Date: time.Now()}
+
+	for name, p := range plugins {
+		t.Run(name, func(t *testing.T) {
+			job, err := p.Extract(raw)
+			if err == nil {
+				t.Fatalf("Extract() = %+v, nil; want a non-nil error for a non-matching subject", job)
+			}
+			if job != nil {
+				t.Errorf("Extract() returned non-nil job %+v alongside an error", job)
+			}
+		})
+	}
+}
+
+func TestExtractLinkedin(t *testing.T) {
+	raw := source.RawEmail{Subject: "your application was sent to Acme", Date: time.Now()}
+	job, err := linkedinPlugin{}.Extract(raw)
+	if err != nil {
+		t.Fatalf("Extract() returned error: %v", err)
+	}
+	if job.Company != "Acme" {
+		t.Errorf("Extract().Company = %q, want %q", job.Company, "Acme")
+	}
+}
+
+func TestFindIsDeterministic(t *testing.T) {
+	// Two distinct fake plugins that both match everything; Find must
+	// always return the one registered first, not whichever a map
+	// iteration happens to land on.
+	savedRegistry, savedOrder := registry, order
+	defer func() { registry, order = savedRegistry, savedOrder }()
+	registry = map[string]Plugin{}
+	order = nil
+
+	first := fakePlugin{name: "first"}
+	second := fakePlugin{name: "second"}
+	Register("first", first)
+	Register("second", second)
+
+	raw := source.RawEmail{Subject: "anything"}
+	for i := 0; i < 10; i++ {
+		got := Find(raw)
+		if got != Plugin(first) {
+			t.Fatalf("Find() = %v, want the first-registered plugin", got)
+		}
+	}
+}
+
+type fakePlugin struct{ name string }
+
+func (fakePlugin) Match(source.RawEmail) bool { return true }
+func (p fakePlugin) Extract(raw source.RawEmail) (*models.Job, error) {
+	return &models.Job{Company: p.name}, nil
+}
@@ -0,0 +1,38 @@
+package plugins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"autojobtracker/models"
+	"autojobtracker/source"
+)
+
+var (
+	wellfoundSender  = regexp.MustCompile(`@(\w+\.)?wellfound\.com$`)
+	wellfoundSubject = regexp.MustCompile(`(?i)you applied to (.+?) at (.+)`)
+)
+
+type wellfoundPlugin struct{}
+
+func (wellfoundPlugin) Match(raw source.RawEmail) bool {
+	return wellfoundSender.MatchString(raw.Email) || strings.Contains(raw.Body, "wellfound.com")
+}
+
+func (wellfoundPlugin) Extract(raw source.RawEmail) (*models.Job, error) {
+	m := wellfoundSubject.FindStringSubmatch(raw.Subject)
+	if m == nil {
+		return nil, fmt.Errorf("plugins: wellfound: subject %q did not match the expected pattern", raw.Subject)
+	}
+	return &models.Job{
+		Email:    raw.Email,
+		Date:     raw.Date,
+		Position: strings.TrimSpace(m[1]),
+		Company:  strings.TrimSpace(m[2]),
+	}, nil
+}
+
+func init() {
+	Register("wellfound", wellfoundPlugin{})
+}
@@ -0,0 +1,38 @@
+package plugins
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"autojobtracker/models"
+	"autojobtracker/source"
+)
+
+var (
+	workdaySender  = regexp.MustCompile(`@(\w+\.)?myworkday(jobs)?\.com$`)
+	workdaySubject = regexp.MustCompile(`(?i)thank you for applying to the (.+?) position at (.+)`)
+)
+
+type workdayPlugin struct{}
+
+func (workdayPlugin) Match(raw source.RawEmail) bool {
+	return workdaySender.MatchString(raw.Email) || strings.Contains(raw.Body, "myworkdayjobs.com")
+}
+
+func (workdayPlugin) Extract(raw source.RawEmail) (*models.Job, error) {
+	m := workdaySubject.FindStringSubmatch(raw.Subject)
+	if m == nil {
+		return nil, fmt.Errorf("plugins: workday: subject %q did not match the expected pattern", raw.Subject)
+	}
+	return &models.Job{
+		Email:    raw.Email,
+		Date:     raw.Date,
+		Position: strings.TrimSpace(m[1]),
+		Company:  strings.TrimSpace(m[2]),
+	}, nil
+}
+
+func init() {
+	Register("workday", workdayPlugin{})
+}
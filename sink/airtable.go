@@ -0,0 +1,132 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"autojobtracker/models"
+)
+
+// AirtableSink upserts one record per job into an Airtable base via its
+// REST API. An existing record is looked up by Company+Position via
+// filterByFormula and PATCHed in place, so repeated status updates for
+// the same application update one row instead of piling up duplicates —
+// the same reconciliation SQLiteSink does locally, via the shared
+// statusRank table.
+type AirtableSink struct {
+	apiKey string
+	baseID string
+	table  string
+}
+
+func NewAirtableSink(apiKey, baseID, table string) *AirtableSink {
+	return &AirtableSink{apiKey: apiKey, baseID: baseID, table: table}
+}
+
+func (s *AirtableSink) Upsert(ctx context.Context, job *models.Job) error {
+	recordID, currentStatus, found, err := s.find(ctx, job.Company, job.Position)
+	if err != nil {
+		return err
+	}
+
+	status := job.Status
+	if found && statusRank[status] < statusRank[currentStatus] {
+		// Don't let a stale or out-of-order email downgrade a status
+		// we've already recorded.
+		status = currentStatus
+	}
+
+	fields := map[string]any{
+		"Company":  job.Company,
+		"Position": job.Position,
+		"Email":    job.Email,
+		"Status":   status,
+		"Date":     job.Date,
+	}
+
+	if found {
+		return s.write(ctx, http.MethodPatch, fmt.Sprintf("https://api.airtable.com/v0/%s/%s/%s", s.baseID, s.table, recordID), fields)
+	}
+	return s.write(ctx, http.MethodPost, fmt.Sprintf("https://api.airtable.com/v0/%s/%s", s.baseID, s.table), fields)
+}
+
+// find looks up the record for company/position, returning found=false
+// if no such record exists yet.
+func (s *AirtableSink) find(ctx context.Context, company, position string) (recordID, status string, found bool, err error) {
+	formula := fmt.Sprintf(`AND({Company}=%s,{Position}=%s)`, airtableQuote(company), airtableQuote(position))
+	u := fmt.Sprintf("https://api.airtable.com/v0/%s/%s?filterByFormula=%s&maxRecords=1",
+		s.baseID, s.table, url.QueryEscape(formula))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", "", false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", "", false, fmt.Errorf("sink: airtable: list records: unexpected status %s", resp.Status)
+	}
+
+	var parsed struct {
+		Records []struct {
+			ID     string `json:"id"`
+			Fields struct {
+				Status string `json:"Status"`
+			} `json:"fields"`
+		} `json:"records"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", "", false, err
+	}
+	if len(parsed.Records) == 0 {
+		return "", "", false, nil
+	}
+	return parsed.Records[0].ID, parsed.Records[0].Fields.Status, true, nil
+}
+
+func (s *AirtableSink) write(ctx context.Context, method, url string, fields map[string]any) error {
+	payload, err := json.Marshal(map[string]any{
+		"fields":   fields,
+		"typecast": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sink: airtable: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *AirtableSink) Close() error { return nil }
+
+// airtableQuote wraps v in double quotes for use inside an Airtable
+// filterByFormula expression, escaping any embedded quotes.
+func airtableQuote(v string) string {
+	return `"` + strings.ReplaceAll(v, `"`, `\"`) + `"`
+}
@@ -0,0 +1,61 @@
+package sink
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Config bundles the credentials any backend might need; a given backend
+// only reads the fields it cares about.
+type Config struct {
+	NotionToken string
+	NotionDB    string
+
+	SQLitePath string
+
+	AirtableKey   string
+	AirtableBase  string
+	AirtableTable string
+
+	SheetsCredentialsFile string
+	SheetsSpreadsheetID   string
+}
+
+// New builds a Sink from the SINK env var, e.g. SINK=notion or a
+// comma-separated list like SINK=notion,sqlite to fan out writes to
+// several backends at once.
+func New(raw string, cfg Config) (Sink, error) {
+	if raw == "" {
+		raw = "notion"
+	}
+
+	names := strings.Split(raw, ",")
+	if len(names) == 1 {
+		return build(strings.TrimSpace(names[0]), cfg)
+	}
+
+	sinks := make(MultiSink, 0, len(names))
+	for _, name := range names {
+		s, err := build(strings.TrimSpace(name), cfg)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+func build(name string, cfg Config) (Sink, error) {
+	switch name {
+	case "notion":
+		return NewNotionSink(cfg.NotionToken, cfg.NotionDB), nil
+	case "sqlite":
+		return NewSQLiteSink(cfg.SQLitePath)
+	case "airtable":
+		return NewAirtableSink(cfg.AirtableKey, cfg.AirtableBase, cfg.AirtableTable), nil
+	case "sheets":
+		return NewSheetsSink(cfg.SheetsCredentialsFile, cfg.SheetsSpreadsheetID)
+	default:
+		return nil, fmt.Errorf("sink: unrecognized SINK %q", name)
+	}
+}
@@ -0,0 +1,34 @@
+package sink
+
+import (
+	"context"
+	"errors"
+
+	"autojobtracker/models"
+)
+
+// MultiSink fans a single Upsert out to every backend, even if an
+// earlier one fails, so one backend being down doesn't also stop the
+// job from reaching the others. Errors from every backend that failed
+// are joined together and returned.
+type MultiSink []Sink
+
+func (m MultiSink) Upsert(ctx context.Context, job *models.Job) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Upsert(ctx, job); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m MultiSink) Close() error {
+	var firstErr error
+	for _, s := range m {
+		if err := s.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
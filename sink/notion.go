@@ -0,0 +1,25 @@
+package sink
+
+import (
+	"context"
+
+	"autojobtracker/models"
+	"autojobtracker/notion"
+)
+
+// NotionSink is the original backend, kept as the default for existing
+// users.
+type NotionSink struct{}
+
+// NewNotionSink initializes the shared Notion client and returns a Sink
+// wrapping it.
+func NewNotionSink(token, databaseID string) *NotionSink {
+	notion.Init(token, databaseID)
+	return &NotionSink{}
+}
+
+func (*NotionSink) Upsert(_ context.Context, job *models.Job) error {
+	return notion.UpdateOrCreate(job)
+}
+
+func (*NotionSink) Close() error { return nil }
@@ -0,0 +1,94 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+
+	"autojobtracker/models"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// SheetsSink keeps one row per job in a Google Sheet, keyed on
+// Company+Position (columns A and B). Upsert reads the sheet to find the
+// matching row and updates it in place rather than appending, so repeated
+// status updates for the same application reconcile onto one row instead
+// of piling up duplicates — the same thing SQLiteSink does locally, via
+// the shared statusRank table.
+type SheetsSink struct {
+	svc           *sheets.Service
+	spreadsheetID string
+}
+
+func NewSheetsSink(credentialsFile, spreadsheetID string) (*SheetsSink, error) {
+	svc, err := sheets.NewService(context.Background(), option.WithCredentialsFile(credentialsFile))
+	if err != nil {
+		return nil, err
+	}
+	return &SheetsSink{svc: svc, spreadsheetID: spreadsheetID}, nil
+}
+
+func (s *SheetsSink) Upsert(ctx context.Context, job *models.Job) error {
+	row, currentStatus, found, err := s.find(ctx, job.Company, job.Position)
+	if err != nil {
+		return err
+	}
+
+	status := job.Status
+	if found && statusRank[status] < statusRank[currentStatus] {
+		// Don't let a stale or out-of-order email downgrade a status
+		// we've already recorded.
+		status = currentStatus
+	}
+
+	values := &sheets.ValueRange{
+		Values: [][]any{{job.Company, job.Position, job.Email, status, job.Date.Format("2006-01-02")}},
+	}
+
+	if found {
+		rng := fmt.Sprintf("Sheet1!A%d:E%d", row, row)
+		_, err := s.svc.Spreadsheets.Values.Update(s.spreadsheetID, rng, values).
+			ValueInputOption("USER_ENTERED").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return fmt.Errorf("sink: sheets: updating row %d: %w", row, err)
+		}
+		return nil
+	}
+
+	_, err = s.svc.Spreadsheets.Values.Append(s.spreadsheetID, "Sheet1!A1", values).
+		ValueInputOption("USER_ENTERED").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return fmt.Errorf("sink: sheets: %w", err)
+	}
+	return nil
+}
+
+// find scans column A:D for a row matching company/position, returning
+// its 1-indexed sheet row and current Status, or found=false if no such
+// row exists yet.
+func (s *SheetsSink) find(ctx context.Context, company, position string) (row int, status string, found bool, err error) {
+	resp, err := s.svc.Spreadsheets.Values.Get(s.spreadsheetID, "Sheet1!A:D").Context(ctx).Do()
+	if err != nil {
+		return 0, "", false, fmt.Errorf("sink: sheets: reading existing rows: %w", err)
+	}
+
+	for i, r := range resp.Values {
+		if len(r) < 2 {
+			continue
+		}
+		if fmt.Sprint(r[0]) == company && fmt.Sprint(r[1]) == position {
+			if len(r) >= 4 {
+				status = fmt.Sprint(r[3])
+			}
+			return i + 1, status, true, nil
+		}
+	}
+	return 0, "", false, nil
+}
+
+func (s *SheetsSink) Close() error { return nil }
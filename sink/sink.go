@@ -0,0 +1,14 @@
+package sink
+
+import (
+	"context"
+
+	"autojobtracker/models"
+)
+
+// Sink persists a parsed job somewhere: Notion, Airtable, Google Sheets,
+// a local SQLite database, ...
+type Sink interface {
+	Upsert(ctx context.Context, job *models.Job) error
+	Close() error
+}
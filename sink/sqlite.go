@@ -0,0 +1,132 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+
+	"autojobtracker/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteSink stores jobs, their status history, and a reference to the
+// email each status came from in a local, normalized SQLite database, so
+// users who don't have (or want) a Notion account can still track
+// applications and query them with SQL.
+type SQLiteSink struct {
+	db *sql.DB
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	company    TEXT NOT NULL,
+	position   TEXT NOT NULL,
+	email      TEXT,
+	status     TEXT NOT NULL,
+	applied_at TEXT,
+	UNIQUE(company, position)
+);
+
+CREATE TABLE IF NOT EXISTS status_history (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	job_id     INTEGER NOT NULL REFERENCES jobs(id),
+	status     TEXT NOT NULL,
+	changed_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS raw_email_refs (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	job_id        INTEGER NOT NULL REFERENCES jobs(id),
+	sender_email  TEXT,
+	received_at   TEXT
+);
+`
+
+// statusRank orders statuses so an Upsert never lets a later, lower-rank
+// status (e.g. a stray "Applied" receipt arriving after an "Offer", or a
+// "Rejected" arriving after an "Offer") knock a job backwards. Offer
+// outranks every other terminal status: once you have one, a stray
+// Rejected/Ghosted email shouldn't erase it.
+var statusRank = map[string]int{
+	"Applied":   0,
+	"Interview": 1,
+	"Rejected":  2,
+	"Ghosted":   2,
+	"Offer":     3,
+}
+
+// NewSQLiteSink opens (creating if necessary) a SQLite database at path
+// and ensures its schema exists.
+func NewSQLiteSink(path string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteSink{db: db}, nil
+}
+
+func (s *SQLiteSink) Upsert(ctx context.Context, job *models.Job) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	var currentStatus string
+	err = tx.QueryRowContext(ctx,
+		`SELECT id, status FROM jobs WHERE company = ? AND position = ?`,
+		job.Company, job.Position,
+	).Scan(&id, &currentStatus)
+
+	switch {
+	case err == sql.ErrNoRows:
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO jobs (company, position, email, status, applied_at) VALUES (?, ?, ?, ?, ?)`,
+			job.Company, job.Position, job.Email, job.Status, job.Date,
+		)
+		if err != nil {
+			return err
+		}
+		if id, err = res.LastInsertId(); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case statusRank[job.Status] < statusRank[currentStatus]:
+		// Don't let a stale or out-of-order email downgrade a status
+		// we've already recorded further along the pipeline.
+		job.Status = currentStatus
+	default:
+		if _, err := tx.ExecContext(ctx, `UPDATE jobs SET status = ? WHERE id = ?`, job.Status, id); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO status_history (job_id, status, changed_at) VALUES (?, ?, ?)`,
+		id, job.Status, job.Date,
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO raw_email_refs (job_id, sender_email, received_at) VALUES (?, ?, ?)`,
+		id, job.Email, job.Date,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteSink) Close() error {
+	return s.db.Close()
+}
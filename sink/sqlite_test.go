@@ -0,0 +1,50 @@
+package sink
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"autojobtracker/models"
+)
+
+func TestSQLiteSinkUpsertReconcilesStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []string
+		wantStatus string
+	}{
+		{"offer survives a later rejected", []string{"Applied", "Interview", "Offer", "Rejected"}, "Offer"},
+		{"offer survives a later ghosted", []string{"Applied", "Offer", "Ghosted"}, "Offer"},
+		{"stray applied after interview is ignored", []string{"Applied", "Interview", "Applied"}, "Interview"},
+		{"interview after applied advances", []string{"Applied", "Interview"}, "Interview"},
+		{"rejected between two offers leaves offer", []string{"Offer", "Rejected", "Offer"}, "Offer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, err := NewSQLiteSink(filepath.Join(t.TempDir(), "jobs.db"))
+			if err != nil {
+				t.Fatalf("NewSQLiteSink() error = %v", err)
+			}
+			defer sink.Close()
+
+			ctx := context.Background()
+			for _, status := range tt.statuses {
+				job := &models.Job{Company: "Acme", Position: "Engineer", Status: status}
+				if err := sink.Upsert(ctx, job); err != nil {
+					t.Fatalf("Upsert(%q) error = %v", status, err)
+				}
+			}
+
+			var got string
+			err = sink.db.QueryRow(`SELECT status FROM jobs WHERE company = ? AND position = ?`, "Acme", "Engineer").Scan(&got)
+			if err != nil {
+				t.Fatalf("querying final status: %v", err)
+			}
+			if got != tt.wantStatus {
+				t.Errorf("final status = %q, want %q", got, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package source
+
+import (
+	"fmt"
+	"strings"
+)
+
+// New builds an EmailSource from the SOURCE env var:
+//
+//	SOURCE unset or "imap"   -> Gmail IMAP over the given credentials
+//	SOURCE=maildir:///path   -> a local Maildir (e.g. a Thunderbird export)
+//	SOURCE=mbox:///path      -> a single mbox file (e.g. a Takeout export)
+func New(raw, imapUser, imapPassword string) (EmailSource, error) {
+	if raw == "" || raw == "imap" {
+		return NewIMAPSource(imapUser, imapPassword)
+	}
+
+	switch {
+	case strings.HasPrefix(raw, "maildir://"):
+		return NewMaildirSource(strings.TrimPrefix(raw, "maildir://"))
+	case strings.HasPrefix(raw, "mbox://"):
+		return NewMboxSource(strings.TrimPrefix(raw, "mbox://"))
+	default:
+		return nil, fmt.Errorf("source: unrecognized SOURCE %q", raw)
+	}
+}
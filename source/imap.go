@@ -0,0 +1,143 @@
+package source
+
+import (
+	"errors"
+	"log"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/emersion/go-message/mail"
+)
+
+// subjectDecoder decodes RFC 2047 encoded-words (e.g. "=?UTF-8?B?...?=").
+// Unlike the body, the IMAP ENVELOPE subject is handed back by the server
+// as-is, so encoded subjects land in the parser as garbage unless decoded
+// here.
+var subjectDecoder = new(mime.WordDecoder)
+
+func decodeSubject(raw string) string {
+	decoded, err := subjectDecoder.DecodeHeader(raw)
+	if err != nil {
+		return raw
+	}
+	return decoded
+}
+
+// IMAPSource fetches job emails from a Gmail mailbox over IMAP
+// (imap.gmail.com). This is the original, always-has-been source.
+type IMAPSource struct {
+	client *client.Client
+}
+
+// NewIMAPSource logs into Gmail's IMAP server as user and selects INBOX.
+func NewIMAPSource(user, password string) (*IMAPSource, error) {
+	c, err := client.DialTLS("imap.gmail.com:993", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Login(user, password); err != nil {
+		c.Logout()
+		return nil, err
+	}
+
+	if _, err := c.Select("INBOX", false); err != nil {
+		c.Logout()
+		return nil, err
+	}
+
+	return &IMAPSource{client: c}, nil
+}
+
+func (s *IMAPSource) Fetch(since time.Time) (<-chan RawEmail, error) {
+	criteria := imap.NewSearchCriteria()
+	criteria.Since = since
+	ids, err := s.client.Search(criteria)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Found %d messages.\n", len(ids))
+
+	out := make(chan RawEmail)
+	if len(ids) == 0 {
+		close(out)
+		return out, nil
+	}
+
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(ids...)
+	section := &imap.BodySectionName{}
+	msgChan := make(chan *imap.Message)
+
+	go func() {
+		if err := s.client.Fetch(seqset, []imap.FetchItem{imap.FetchEnvelope, section.FetchItem()}, msgChan); err != nil {
+			log.Println("IMAP fetch error:", err)
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for msg := range msgChan {
+			raw, err := FromIMAPMessage(msg, section)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			out <- raw
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *IMAPSource) Close() error {
+	return s.client.Logout()
+}
+
+// Client exposes the underlying IMAP client for callers, such as the
+// sync package, that need lower-level operations Fetch doesn't cover
+// (UID search, IDLE, ...).
+func (s *IMAPSource) Client() *client.Client {
+	return s.client
+}
+
+// FromIMAPMessage converts a fetched *imap.Message (envelope + the body
+// section named by section) into a RawEmail. It's exported so the sync
+// package, which fetches by UID directly rather than going through
+// Fetch, can reuse the same body/sender extraction.
+func FromIMAPMessage(msg *imap.Message, section *imap.BodySectionName) (RawEmail, error) {
+	if msg.Envelope == nil {
+		return RawEmail{}, errors.New("source: message has no envelope")
+	}
+
+	r := msg.GetBody(section)
+	if r == nil {
+		return RawEmail{}, errors.New("source: message has no body")
+	}
+
+	mr, err := mail.CreateReader(r)
+	if err != nil {
+		return RawEmail{}, err
+	}
+
+	return RawEmail{
+		Subject: strings.ToLower(decodeSubject(msg.Envelope.Subject)),
+		Body:    bodyFromReader(mr),
+		Email:   envelopeSender(msg),
+		Date:    msg.Envelope.Date,
+	}, nil
+}
+
+func envelopeSender(msg *imap.Message) string {
+	if msg.Envelope == nil || len(msg.Envelope.From) == 0 {
+		log.Println("No sender info in email")
+		return ""
+	}
+	from := msg.Envelope.From[0]
+	email := from.MailboxName + "@" + from.HostName
+	log.Printf("Sender email: %s", email)
+	return email
+}
@@ -0,0 +1,60 @@
+package source
+
+import (
+	"log"
+	"time"
+
+	maildir "github.com/emersion/go-maildir"
+)
+
+// MaildirSource reads RFC822 messages out of a local Maildir, such as one
+// exported from Thunderbird. It has no persisted sync state, so Fetch
+// re-scans the whole directory and filters by message date every run.
+// Messages still in new/ are moved into cur/ before the scan.
+type MaildirSource struct {
+	dir maildir.Dir
+}
+
+// NewMaildirSource opens a Maildir rooted at path (must contain cur/,
+// new/ and tmp/ subdirectories).
+func NewMaildirSource(path string) (*MaildirSource, error) {
+	return &MaildirSource{dir: maildir.Dir(path)}, nil
+}
+
+func (s *MaildirSource) Fetch(since time.Time) (<-chan RawEmail, error) {
+	// Keys and Open only look in cur/, so pull anything in new/ over first.
+	if _, err := s.dir.Unseen(); err != nil {
+		return nil, err
+	}
+	keys, err := s.dir.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan RawEmail)
+	go func() {
+		defer close(out)
+		for _, key := range keys {
+			f, err := s.dir.Open(key)
+			if err != nil {
+				log.Printf("maildir: failed to open %s: %v", key, err)
+				continue
+			}
+
+			raw, err := parseRFC822(f)
+			f.Close()
+			if err != nil {
+				log.Printf("maildir: failed to parse %s: %v", key, err)
+				continue
+			}
+			if raw.Date.Before(since) {
+				continue
+			}
+			out <- raw
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *MaildirSource) Close() error { return nil }
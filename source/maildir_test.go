@@ -0,0 +1,67 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeMaildirMessage(t *testing.T, dir, filename, rfc822 string) {
+	t.Helper()
+	for _, sub := range []string{"cur", "new", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatalf("MkdirAll(%s) error = %v", sub, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new", filename), []byte(rfc822), 0644); err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", filename, err)
+	}
+}
+
+func TestMaildirSourceFetchFiltersBySince(t *testing.T) {
+	dir := t.TempDir()
+
+	old := "From: old@acme.com\r\n" +
+		"Subject: Old application\r\n" +
+		"Date: Mon, 01 Jan 2024 10:00:00 +0000\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"old message\r\n"
+	writeMaildirMessage(t, dir, "1.old.hostname", old)
+
+	recent := "From: new@acme.com\r\n" +
+		"Subject: Your application to Acme\r\n" +
+		"Date: Tue, 01 Jul 2025 10:00:00 +0000\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"recent message\r\n"
+	writeMaildirMessage(t, dir, "2.recent.hostname", recent)
+
+	src, err := NewMaildirSource(dir)
+	if err != nil {
+		t.Fatalf("NewMaildirSource() error = %v", err)
+	}
+	defer src.Close()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	out, err := src.Fetch(since)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	var got []RawEmail
+	for raw := range out {
+		got = append(got, raw)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("Fetch() returned %d messages, want 1 (the one on/after %s)", len(got), since)
+	}
+	if got[0].Email != "new@acme.com" {
+		t.Errorf("Email = %q, want %q", got[0].Email, "new@acme.com")
+	}
+	if got[0].Subject != "your application to acme" {
+		t.Errorf("Subject = %q, want the lowercased subject", got[0].Subject)
+	}
+}
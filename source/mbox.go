@@ -0,0 +1,57 @@
+package source
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/emersion/go-mbox"
+)
+
+// MboxSource reads RFC822 messages out of a single mbox file, such as a
+// Google Takeout export. Like MaildirSource it has no persisted sync
+// state and filters by message date on every run.
+type MboxSource struct {
+	path string
+}
+
+// NewMboxSource points at an mbox file on disk. The file isn't opened
+// until Fetch is called.
+func NewMboxSource(path string) (*MboxSource, error) {
+	return &MboxSource{path: path}, nil
+}
+
+func (s *MboxSource) Fetch(since time.Time) (<-chan RawEmail, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan RawEmail)
+	go func() {
+		defer close(out)
+		defer f.Close()
+
+		r := mbox.NewReader(f)
+		for {
+			msg, err := r.NextMessage()
+			if err != nil {
+				break
+			}
+
+			raw, err := parseRFC822(msg)
+			if err != nil {
+				log.Println("mbox: failed to parse message:", err)
+				continue
+			}
+			if raw.Date.Before(since) {
+				continue
+			}
+			out <- raw
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *MboxSource) Close() error { return nil }
@@ -0,0 +1,58 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMboxSourceFetchFiltersBySince(t *testing.T) {
+	content := "From old@acme.com Mon Jan 01 10:00:00 2024\r\n" +
+		"From: old@acme.com\r\n" +
+		"Subject: Old application\r\n" +
+		"Date: Mon, 01 Jan 2024 10:00:00 +0000\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"old message\r\n" +
+		"\r\n" +
+		"From new@acme.com Tue Jul 01 10:00:00 2025\r\n" +
+		"From: new@acme.com\r\n" +
+		"Subject: Your application to Acme\r\n" +
+		"Date: Tue, 01 Jul 2025 10:00:00 +0000\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"recent message\r\n"
+
+	path := filepath.Join(t.TempDir(), "archive.mbox")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	src, err := NewMboxSource(path)
+	if err != nil {
+		t.Fatalf("NewMboxSource() error = %v", err)
+	}
+	defer src.Close()
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	out, err := src.Fetch(since)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	var got []RawEmail
+	for raw := range out {
+		got = append(got, raw)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("Fetch() returned %d messages, want 1 (the one on/after %s)", len(got), since)
+	}
+	if got[0].Email != "new@acme.com" {
+		t.Errorf("Email = %q, want %q", got[0].Email, "new@acme.com")
+	}
+	if got[0].Subject != "your application to acme" {
+		t.Errorf("Subject = %q, want the lowercased subject", got[0].Subject)
+	}
+}
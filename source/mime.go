@@ -0,0 +1,96 @@
+package source
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"mime"
+	"strings"
+
+	"github.com/emersion/go-message/mail"
+	"github.com/jaytaylor/html2text"
+)
+
+// bodyFromReader extracts the text/plain part of a parsed RFC822 message,
+// falling back to a rendered text/html part if no plain text is present.
+// go-message/mail already decodes each part's Content-Transfer-Encoding
+// (quoted-printable, base64, ...) before handing it back via p.Body, so
+// there's nothing left to decode here.
+func bodyFromReader(mr *mail.Reader) string {
+	var htmlBody string
+
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Println("NextPart error:", err)
+			break
+		}
+
+		contentType := p.Header.Get("Content-Type")
+		if contentType == "" {
+			continue
+		}
+
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			log.Println("Failed to parse media type:", err)
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		if _, err := buf.ReadFrom(p.Body); err != nil {
+			log.Println("ReadFrom error:", err)
+			continue
+		}
+
+		if strings.HasPrefix(mediaType, "text/plain") {
+			return buf.String()
+		}
+		if strings.HasPrefix(mediaType, "text/html") {
+			htmlBody = buf.String()
+		}
+	}
+
+	if htmlBody != "" {
+		return stripHTMLTags(htmlBody)
+	}
+	return ""
+}
+
+// parseRFC822 turns a raw message, as stored by a Maildir or mbox archive,
+// into a RawEmail. The IMAP source doesn't use this: it already gets
+// subject/date/sender for free from the server envelope.
+func parseRFC822(r io.Reader) (RawEmail, error) {
+	mr, err := mail.CreateReader(r)
+	if err != nil {
+		return RawEmail{}, err
+	}
+
+	var raw RawEmail
+	if from, err := mr.Header.AddressList("From"); err == nil && len(from) > 0 {
+		raw.Email = from[0].Address
+	}
+	if subject, err := mr.Header.Subject(); err == nil {
+		raw.Subject = strings.ToLower(subject)
+	}
+	if date, err := mr.Header.Date(); err == nil {
+		raw.Date = date
+	}
+	raw.Body = bodyFromReader(mr)
+	return raw, nil
+}
+
+// stripHTMLTags renders an HTML body down to plain text. A naive
+// <[^>]*> regex used to do this and mangled <script>/<style> content;
+// html2text walks the actual DOM and skips non-visible elements.
+func stripHTMLTags(html string) string {
+	text, err := html2text.FromString(html, html2text.Options{PrettyTables: false})
+	if err != nil {
+		log.Println("html2text error:", err)
+		return strings.TrimSpace(html)
+	}
+	return strings.TrimSpace(text)
+}
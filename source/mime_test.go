@@ -0,0 +1,101 @@
+package source
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripHTMLTagsDropsScriptAndStyleContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		want    string
+		mustNot []string
+	}{
+		{
+			name:    "script tag content is dropped",
+			html:    `<p>Thanks for applying.</p><script>alert("hi")</script>`,
+			want:    "Thanks for applying.",
+			mustNot: []string{"alert", "hi"},
+		},
+		{
+			name:    "style tag content is dropped",
+			html:    `<style>body { color: red; }</style><p>We received your application.</p>`,
+			want:    "We received your application.",
+			mustNot: []string{"color", "red"},
+		},
+		{
+			name: "ordinary formatting collapses to plain text",
+			html: `<div><b>Acme</b> <i>Corp</i></div>`,
+			want: "Acme Corp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stripHTMLTags(tt.html)
+			if got != tt.want {
+				t.Errorf("stripHTMLTags(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+			for _, s := range tt.mustNot {
+				if strings.Contains(got, s) {
+					t.Errorf("stripHTMLTags(%q) = %q, must not contain %q", tt.html, got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRFC822PrefersPlainTextPart(t *testing.T) {
+	msg := "From: recruiter@acme.com\r\n" +
+		"Subject: Your application to Acme\r\n" +
+		"Date: Tue, 01 Jul 2025 10:00:00 +0000\r\n" +
+		"Content-Type: multipart/alternative; boundary=BOUNDARY\r\n" +
+		"\r\n" +
+		"--BOUNDARY\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"Thanks for applying!\r\n" +
+		"--BOUNDARY\r\n" +
+		"Content-Type: text/html\r\n" +
+		"\r\n" +
+		"<p>Thanks for applying!</p><script>evil()</script>\r\n" +
+		"--BOUNDARY--\r\n"
+
+	raw, err := parseRFC822(strings.NewReader(msg))
+	if err != nil {
+		t.Fatalf("parseRFC822() error = %v", err)
+	}
+
+	if raw.Email != "recruiter@acme.com" {
+		t.Errorf("Email = %q, want %q", raw.Email, "recruiter@acme.com")
+	}
+	if raw.Subject != "your application to acme" {
+		t.Errorf("Subject = %q, want lowercased subject", raw.Subject)
+	}
+	if strings.TrimSpace(raw.Body) != "Thanks for applying!" {
+		t.Errorf("Body = %q, want the plain-text part", raw.Body)
+	}
+}
+
+func TestParseRFC822FallsBackToHTMLPart(t *testing.T) {
+	msg := "From: recruiter@acme.com\r\n" +
+		"Subject: Your application to Acme\r\n" +
+		"Date: Tue, 01 Jul 2025 10:00:00 +0000\r\n" +
+		"Content-Type: text/html\r\n" +
+		"\r\n" +
+		"<p>Thanks for applying!</p><script>evil()</script>\r\n"
+
+	raw, err := parseRFC822(strings.NewReader(msg))
+	if err != nil {
+		t.Fatalf("parseRFC822() error = %v", err)
+	}
+
+	body := strings.TrimSpace(raw.Body)
+	if !strings.Contains(body, "Thanks for applying!") {
+		t.Errorf("Body = %q, want it to contain the rendered HTML text", body)
+	}
+	if strings.Contains(body, "evil") {
+		t.Errorf("Body = %q, must not contain stripped <script> content", body)
+	}
+}
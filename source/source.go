@@ -0,0 +1,21 @@
+package source
+
+import "time"
+
+// RawEmail is a fetched message reduced to the fields the parser cares
+// about. Subject is already lowercased, matching what the IMAP path has
+// always handed to the parser.
+type RawEmail struct {
+	Subject string
+	Body    string
+	Email   string
+	Date    time.Time
+}
+
+// EmailSource fetches raw emails from a mailbox or a local archive.
+type EmailSource interface {
+	// Fetch returns a channel of messages dated on or after since. The
+	// channel is closed once every matching message has been sent.
+	Fetch(since time.Time) (<-chan RawEmail, error)
+	Close() error
+}
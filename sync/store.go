@@ -0,0 +1,74 @@
+package sync
+
+import (
+	"encoding/binary"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var bucketName = []byte("mailbox_state")
+
+// State is the last position we've synced a mailbox to.
+type State struct {
+	UIDValidity uint32
+	LastUID     uint32
+}
+
+// Store persists per-mailbox sync state across runs.
+type Store interface {
+	Load(mailbox string) (State, error)
+	Save(mailbox string, s State) error
+	Close() error
+}
+
+// BoltStore persists state in a single BoltDB file, one 8-byte record per
+// mailbox: 4 bytes UIDVALIDITY followed by 4 bytes of the last-seen UID.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB file at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (b *BoltStore) Load(mailbox string) (State, error) {
+	var s State
+	err := b.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketName).Get([]byte(mailbox))
+		if len(v) < 8 {
+			return nil
+		}
+		s.UIDValidity = binary.BigEndian.Uint32(v[0:4])
+		s.LastUID = binary.BigEndian.Uint32(v[4:8])
+		return nil
+	})
+	return s, err
+}
+
+func (b *BoltStore) Save(mailbox string, s State) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		buf := make([]byte, 8)
+		binary.BigEndian.PutUint32(buf[0:4], s.UIDValidity)
+		binary.BigEndian.PutUint32(buf[4:8], s.LastUID)
+		return tx.Bucket(bucketName).Put([]byte(mailbox), buf)
+	})
+}
+
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,85 @@
+package sync
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBoltStoreLoadMissingMailboxReturnsZeroState(t *testing.T) {
+	store, err := NewBoltStore(filepath.Join(t.TempDir(), "sync_state.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	defer store.Close()
+
+	state, err := store.Load("INBOX")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if state != (State{}) {
+		t.Errorf("Load() on an unseen mailbox = %+v, want the zero State", state)
+	}
+}
+
+func TestBoltStoreSaveLoadRoundTrip(t *testing.T) {
+	store, err := NewBoltStore(filepath.Join(t.TempDir(), "sync_state.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	defer store.Close()
+
+	want := State{UIDValidity: 42, LastUID: 1000}
+	if err := store.Save("INBOX", want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := store.Load("INBOX")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBoltStoreTracksMailboxesIndependently(t *testing.T) {
+	store, err := NewBoltStore(filepath.Join(t.TempDir(), "sync_state.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	defer store.Close()
+
+	if err := store.Save("INBOX", State{UIDValidity: 1, LastUID: 10}); err != nil {
+		t.Fatalf("Save(INBOX) error = %v", err)
+	}
+	if err := store.Save("Archive", State{UIDValidity: 2, LastUID: 20}); err != nil {
+		t.Fatalf("Save(Archive) error = %v", err)
+	}
+
+	inbox, err := store.Load("INBOX")
+	if err != nil {
+		t.Fatalf("Load(INBOX) error = %v", err)
+	}
+	if inbox != (State{UIDValidity: 1, LastUID: 10}) {
+		t.Errorf("Load(INBOX) = %+v, want {UIDValidity: 1, LastUID: 10}", inbox)
+	}
+
+	archive, err := store.Load("Archive")
+	if err != nil {
+		t.Fatalf("Load(Archive) error = %v", err)
+	}
+	if archive != (State{UIDValidity: 2, LastUID: 20}) {
+		t.Errorf("Load(Archive) = %+v, want {UIDValidity: 2, LastUID: 20}", archive)
+	}
+}
+
+// A mailbox with no prior state has LastUID 0, so syncOnce's
+// criteria.Uid.AddRange(state.LastUID+1, 0) must search starting at UID
+// 1, not UID 0 (UID 0 is not a valid message UID and some servers treat
+// it as "no lower bound" in a search range).
+func TestZeroStateSearchesFromUIDOne(t *testing.T) {
+	var state State
+	if got, want := state.LastUID+1, uint32(1); got != want {
+		t.Errorf("zero-value State.LastUID+1 = %d, want %d", got, want)
+	}
+}
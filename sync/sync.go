@@ -0,0 +1,138 @@
+package sync
+
+import (
+	"log"
+	"time"
+
+	"autojobtracker/source"
+
+	"github.com/emersion/go-imap"
+	idle "github.com/emersion/go-imap-idle"
+	"github.com/emersion/go-imap/client"
+)
+
+// Syncer performs incremental, UID-based sync against a single IMAP
+// mailbox, falling back to polling when the server doesn't support IDLE.
+type Syncer struct {
+	src      *source.IMAPSource
+	store    Store
+	mailbox  string
+	interval time.Duration
+}
+
+// NewSyncer wires an IMAP source up to a persistent Store. interval is
+// the polling period used when the server doesn't support IDLE.
+func NewSyncer(src *source.IMAPSource, store Store, mailbox string, interval time.Duration) *Syncer {
+	return &Syncer{src: src, store: store, mailbox: mailbox, interval: interval}
+}
+
+// Run syncs once immediately, then blocks forever: re-syncing whenever
+// the server pushes an EXISTS update via IDLE, or, failing that, every
+// interval. handle is called once per new message, in UID order.
+func (s *Syncer) Run(handle func(source.RawEmail)) error {
+	for {
+		if err := s.syncOnce(handle); err != nil {
+			return err
+		}
+
+		if err := s.waitForChange(); err != nil {
+			log.Printf("sync: IDLE unavailable, falling back to polling every %s: %v", s.interval, err)
+			time.Sleep(s.interval)
+		}
+	}
+}
+
+func (s *Syncer) syncOnce(handle func(source.RawEmail)) error {
+	c := s.src.Client()
+
+	status, err := c.Status(s.mailbox, []imap.StatusItem{imap.StatusUidValidity, imap.StatusUidNext})
+	if err != nil {
+		return err
+	}
+
+	state, err := s.store.Load(s.mailbox)
+	if err != nil {
+		return err
+	}
+	if state.UIDValidity != status.UidValidity {
+		log.Printf("sync: UIDVALIDITY changed for %s, resyncing from scratch", s.mailbox)
+		state = State{UIDValidity: status.UidValidity}
+	}
+
+	criteria := imap.NewSearchCriteria()
+	criteria.Uid = new(imap.SeqSet)
+	criteria.Uid.AddRange(state.LastUID+1, 0)
+
+	uids, err := c.UidSearch(criteria)
+	if err != nil {
+		return err
+	}
+	// "LastUID+1:*" still matches the highest existing UID when nothing
+	// newer has arrived, so drop anything already handled.
+	fresh := uids[:0]
+	for _, uid := range uids {
+		if uid > state.LastUID {
+			fresh = append(fresh, uid)
+		}
+	}
+	uids = fresh
+	if len(uids) == 0 {
+		return nil
+	}
+	log.Printf("sync: %d new message(s) in %s", len(uids), s.mailbox)
+
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+	section := &imap.BodySectionName{}
+	msgChan := make(chan *imap.Message, 10)
+
+	go func() {
+		if err := c.UidFetch(seqset, []imap.FetchItem{imap.FetchUid, imap.FetchEnvelope, section.FetchItem()}, msgChan); err != nil {
+			log.Println("sync: UID fetch error:", err)
+		}
+	}()
+
+	var maxUID uint32
+	for msg := range msgChan {
+		if msg.Uid > maxUID {
+			maxUID = msg.Uid
+		}
+		raw, err := source.FromIMAPMessage(msg, section)
+		if err != nil {
+			log.Println("sync:", err)
+			continue
+		}
+		handle(raw)
+	}
+
+	if maxUID > state.LastUID {
+		state.LastUID = maxUID
+	}
+	return s.store.Save(s.mailbox, state)
+}
+
+// waitForChange blocks until the server sends a mailbox update. It uses
+// IDLE where the server supports it and otherwise polls with NOOP every
+// s.interval. It returns an error if the IDLE or NOOP command fails, and
+// the caller then sleeps for s.interval before syncing again.
+func (s *Syncer) waitForChange() error {
+	c := s.src.Client()
+	idleClient := idle.NewClient(c)
+
+	updates := make(chan client.Update)
+	c.Updates = updates
+	defer func() { c.Updates = nil }()
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() { done <- idleClient.IdleWithFallback(stop, s.interval) }()
+
+	select {
+	case <-updates:
+		close(stop)
+		<-done
+		return nil
+	case err := <-done:
+		return err
+	}
+}